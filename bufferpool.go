@@ -0,0 +1,63 @@
+package sse
+
+import "sync"
+
+// BufferPool lets callers reduce per-event allocations by reusing the byte
+// buffers Stream uses internally to assemble event data across calls to
+// Recv. Get must return a buffer with at least the requested capacity and
+// a length of zero; Put returns a buffer obtained from Get once Stream is
+// done with it.
+type BufferPool interface {
+	Get(length int) *[]byte
+	Put(*[]byte)
+}
+
+// syncPoolBufferPool is the default BufferPool, used whenever StreamConfig
+// doesn't set one explicitly.
+type syncPoolBufferPool struct {
+	pool sync.Pool
+}
+
+func newSyncPoolBufferPool() *syncPoolBufferPool {
+	return &syncPoolBufferPool{
+		pool: sync.Pool{
+			New: func() any {
+				b := make([]byte, 0, 512)
+				return &b
+			},
+		},
+	}
+}
+
+func (p *syncPoolBufferPool) Get(length int) *[]byte {
+	b := p.pool.Get().(*[]byte)
+	if cap(*b) < length {
+		*b = make([]byte, 0, length)
+	} else {
+		*b = (*b)[:0]
+	}
+	return b
+}
+
+func (p *syncPoolBufferPool) Put(b *[]byte) {
+	p.pool.Put(b)
+}
+
+// defaultBufferPool is shared by every Stream that doesn't configure its own
+// BufferPool. sync.Pool is safe for concurrent use, so streams can share it.
+var defaultBufferPool = newSyncPoolBufferPool()
+
+// NopBufferPool is a BufferPool that never reuses buffers; every Get
+// allocates fresh, and Put is a no-op. It has no effect on Event.Data's
+// lifetime — Data is always copied out of the scratch buffer before Recv
+// returns it, regardless of which BufferPool is configured. Use it if you'd
+// rather not share a pool across streams, e.g. to keep allocation profiles
+// attributable per call.
+type NopBufferPool struct{}
+
+func (NopBufferPool) Get(length int) *[]byte {
+	b := make([]byte, 0, length)
+	return &b
+}
+
+func (NopBufferPool) Put(*[]byte) {}
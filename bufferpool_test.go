@@ -0,0 +1,94 @@
+package sse_test
+
+import (
+	"testing"
+
+	"github.com/tmaxmax/go-sse"
+	"github.com/tmaxmax/go-sse/internal/tests"
+)
+
+// countingBufferPool wraps a plain allocation per Get/Put call, recording
+// how many of each happened.
+type countingBufferPool struct {
+	gets, puts int
+}
+
+func (p *countingBufferPool) Get(length int) *[]byte {
+	p.gets++
+	b := make([]byte, 0, length)
+	return &b
+}
+
+func (p *countingBufferPool) Put(*[]byte) {
+	p.puts++
+}
+
+func TestStreamConfig_BufferPool_GetAndPutBalanced(t *testing.T) {
+	input := "data: First\n\ndata: Second\n\n"
+	r := newTestReadCloser(input)
+	pool := &countingBufferPool{}
+	cfg := &sse.StreamConfig{BufferPool: pool}
+	stream := sse.NewStreamWithConfig(r, cfg)
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Data, "First", "unexpected first event data")
+
+	event, err = stream.Recv()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Data, "Second", "unexpected second event data")
+
+	tests.Equal(t, pool.gets, 2, "expected one Get per event with data")
+	tests.Equal(t, pool.puts, 2, "expected the scratch buffer to be returned after each event")
+}
+
+func TestStreamConfig_BufferPool_EventWithoutDataSkipsPool(t *testing.T) {
+	input := "event: ping\n\n"
+	r := newTestReadCloser(input)
+	pool := &countingBufferPool{}
+	cfg := &sse.StreamConfig{BufferPool: pool}
+	stream := sse.NewStreamWithConfig(r, cfg)
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Type, "ping", "unexpected event type")
+	tests.Equal(t, pool.gets, 0, "an event with no data field shouldn't touch the pool")
+}
+
+func TestStream_NopBufferPool(t *testing.T) {
+	input := "data: Hello\n\n"
+	r := newTestReadCloser(input)
+	cfg := &sse.StreamConfig{BufferPool: sse.NopBufferPool{}}
+	stream := sse.NewStreamWithConfig(r, cfg)
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Data, "Hello", "unexpected event data with NopBufferPool")
+}
+
+func TestStream_DefaultBufferPool_ReusesAcrossEvents(t *testing.T) {
+	// Exercise the default sync.Pool-backed BufferPool directly (no custom
+	// StreamConfig.BufferPool set) across multiple events on the same
+	// stream, which is the scenario the pooling was added for.
+	input := "data: one\n\ndata: two\n\ndata: three\n\n"
+	r := newTestReadCloser(input)
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	var got []string
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		got = append(got, event.Data)
+	}
+
+	tests.Equal(t, len(got), 3, "unexpected number of events")
+	tests.Equal(t, got[0], "one", "unexpected first event data")
+	tests.Equal(t, got[1], "two", "unexpected second event data")
+	tests.Equal(t, got[2], "three", "unexpected third event data")
+}
@@ -0,0 +1,172 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// isContextErr reports whether err is the error ctxReader substitutes for a
+// read it force-aborted on behalf of RecvContext/All, as opposed to a
+// genuine transport failure. Stream treats the two differently: a context
+// cancellation shouldn't permanently close the stream the way a real
+// transport error does.
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// ErrStreamUnusable is returned once a context cancellation or timeout
+// aborts a pending read on a reader that doesn't implement deadlineReader
+// (e.g. a plain io.ReadCloser, or an http.Response.Body without a net.Conn
+// underneath). Aborting such a read works by closing the internal pipe the
+// pump goroutine writes into, which can drop bytes already consumed from
+// the underlying reader, so there's no safe way to resume the Stream
+// afterward: every read after the one that triggered the abort reports this
+// error, rather than reusing the original (by then unrelated) ctx.Err().
+//
+// Streams backed by a reader that implements deadlineReader, such as
+// net.Conn, don't have this limitation: see Stream.RecvContext.
+var ErrStreamUnusable = errors.New("sse: stream unusable after a context cancellation aborted a pending read")
+
+// deadlineReader is implemented by readers that support read deadlines,
+// such as net.Conn. ctxReader prefers it over the pipe-based fallback, as
+// it needs no extra goroutine to pump data.
+type deadlineReader interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// ctxReader wraps a Stream's underlying reader so a single pending Read can
+// be aborted when a context passed to RecvContext is cancelled, without
+// requiring the reader itself to support cancellation.
+type ctxReader struct {
+	io.ReadCloser
+	deadline deadlineReader
+
+	// cancelErr holds the ctx.Err() of the watch call currently force-aborting
+	// a read, if any, so Read can report that instead of the raw OS-level
+	// error (e.g. a deadline timeout) the abort produces.
+	cancelErr atomic.Pointer[error]
+
+	// pipeBroken is set once a cancellation has closed the pipe fallback
+	// path for good. Unlike the deadline path, that close can't be undone,
+	// so every read after the one that triggered it must report
+	// ErrStreamUnusable instead of reusing cancelErr, which by then refers to
+	// an unrelated, already-resolved cancellation.
+	pipeBroken atomic.Bool
+
+	pumpOnce sync.Once
+	pr       *io.PipeReader
+	pw       *io.PipeWriter
+}
+
+func newCtxReader(r io.ReadCloser) *ctxReader {
+	cr := &ctxReader{ReadCloser: r}
+	cr.deadline, _ = r.(deadlineReader)
+	return cr
+}
+
+// Read serves from the pump pipe once it has been started by watch; until
+// then it reads directly from the wrapped reader, so RecvContext costs
+// nothing when it is never called. If watch force-aborted this read, the
+// error returned is ctx.Err() itself rather than the raw timeout/pipe error,
+// so callers can tell a cancellation apart from a genuine transport failure.
+// On the pipe fallback path that changes once the pipe has been left
+// permanently broken by a past cancellation: every read after that one
+// reports ErrStreamUnusable instead, regardless of which ctx triggered it.
+func (c *ctxReader) Read(p []byte) (int, error) {
+	var n int
+	var err error
+	if c.pr != nil {
+		n, err = c.pr.Read(p)
+	} else {
+		n, err = c.ReadCloser.Read(p)
+	}
+
+	if err != nil {
+		if c.pipeBroken.Load() {
+			err = ErrStreamUnusable
+		} else if ce := c.cancelErr.Load(); ce != nil {
+			err = *ce
+		}
+	}
+	return n, err
+}
+
+// watch arranges for the read in flight during the call to be interrupted
+// when ctx is done, and returns a cleanup function the caller must invoke
+// once that read returns.
+func (c *ctxReader) watch(ctx context.Context) (cleanup func()) {
+	if c.deadline != nil {
+		return c.watchDeadline(ctx)
+	}
+	return c.watchPipe(ctx)
+}
+
+func (c *ctxReader) watchDeadline(ctx context.Context) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			err := ctx.Err()
+			c.cancelErr.Store(&err)
+			c.deadline.SetReadDeadline(time.Unix(0, 1))
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		// Clearing the deadline, not just the cancelErr, is what makes the
+		// reader usable again on a later call with a fresh context.
+		c.deadline.SetReadDeadline(time.Time{})
+		c.cancelErr.Store(nil)
+	}
+}
+
+func (c *ctxReader) watchPipe(ctx context.Context) func() {
+	if c.pipeBroken.Load() {
+		// A previous cancellation already broke the pipe for good; nothing
+		// left to watch here, Read reports ErrStreamUnusable on its own.
+		return func() {}
+	}
+
+	c.pumpOnce.Do(func() {
+		c.pr, c.pw = io.Pipe()
+		go func() {
+			_, err := io.Copy(c.pw, c.ReadCloser)
+			if err == nil {
+				err = io.EOF
+			}
+			c.pw.CloseWithError(err)
+		}()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			err := ctx.Err()
+			c.cancelErr.Store(&err)
+			c.pr.CloseWithError(err)
+		case <-done:
+		}
+	}()
+
+	// Unlike watchDeadline, a cancellation here leaves the pipe permanently
+	// broken: closing pr can drop bytes the pump goroutine already consumed
+	// from the real reader, so there's no safe way to keep using this Stream
+	// afterward. The read this call aborted (if any) still reports the real
+	// ctx.Err(), same as the deadline path, but pipeBroken ensures every read
+	// after it gets the unambiguous ErrStreamUnusable instead of reusing
+	// cancelErr, which would otherwise look like a fresh, unrelated call's
+	// own context had been cancelled.
+	return func() {
+		close(done)
+		if c.cancelErr.Load() != nil {
+			c.pipeBroken.Store(true)
+		}
+	}
+}
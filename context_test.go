@@ -0,0 +1,123 @@
+package sse_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tmaxmax/go-sse"
+	"github.com/tmaxmax/go-sse/internal/tests"
+)
+
+// fakeConn is a minimal io.ReadCloser that also implements SetReadDeadline,
+// like a net.Conn, so ctxReader's deadline-based cancellation path can be
+// exercised without a real network connection.
+type fakeConn struct {
+	mu       sync.Mutex
+	buf      []byte
+	deadline time.Time
+	closed   bool
+}
+
+func (f *fakeConn) push(s string) {
+	f.mu.Lock()
+	f.buf = append(f.buf, s...)
+	f.mu.Unlock()
+}
+
+func (f *fakeConn) SetReadDeadline(t time.Time) error {
+	f.mu.Lock()
+	f.deadline = t
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *fakeConn) Read(p []byte) (int, error) {
+	for {
+		f.mu.Lock()
+		if len(f.buf) > 0 {
+			n := copy(p, f.buf)
+			f.buf = f.buf[n:]
+			f.mu.Unlock()
+			return n, nil
+		}
+		deadline, closed := f.deadline, f.closed
+		f.mu.Unlock()
+
+		if closed {
+			return 0, io.EOF
+		}
+		if !deadline.IsZero() && !time.Now().Before(deadline) {
+			return 0, os.ErrDeadlineExceeded
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func (f *fakeConn) Close() error {
+	f.mu.Lock()
+	f.closed = true
+	f.mu.Unlock()
+	return nil
+}
+
+func TestStream_RecvContext_AlreadyCancelled(t *testing.T) {
+	r := newTestReadCloser("data: test\n\n")
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := stream.RecvContext(ctx)
+	tests.Equal(t, err, context.Canceled, "expected context.Canceled")
+}
+
+func TestStream_RecvContext_PipeFallbackUnusableAfterCancel(t *testing.T) {
+	// pr doesn't implement deadlineReader (unlike fakeConn above), so this
+	// exercises ctxReader's pipe-based fallback path, which is what wraps a
+	// plain io.ReadCloser or an http.Response.Body without a net.Conn
+	// underneath — the most common real-world SSE transport.
+	pr, pw := io.Pipe()
+	defer pw.Close()
+
+	stream := sse.NewStream(pr)
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := stream.RecvContext(ctx)
+	tests.Expect(t, err != nil, "expected an error from the timed-out read")
+	tests.Equal(t, ctx.Err(), context.DeadlineExceeded, "context should have timed out")
+
+	// Unlike the deadline-capable path, the pipe fallback can't safely
+	// recover from an aborted read, since doing so can drop bytes already
+	// consumed from the underlying reader. A later call, even with a fresh,
+	// never-cancelled context, must get a clear ErrStreamUnusable instead of
+	// silently reusing the first call's now-unrelated context.DeadlineExceeded.
+	_, err = stream.RecvContext(context.Background())
+	tests.Equal(t, err, sse.ErrStreamUnusable, "expected ErrStreamUnusable, not a stale error from the earlier cancellation")
+}
+
+func TestStream_RecvContext_TimeoutThenContinuedUse(t *testing.T) {
+	conn := &fakeConn{}
+	stream := sse.NewStream(conn)
+	defer stream.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := stream.RecvContext(ctx)
+	tests.Expect(t, err != nil, "expected an error from the timed-out read")
+	tests.Equal(t, ctx.Err(), context.DeadlineExceeded, "context should have timed out")
+
+	conn.push("data: after timeout\n\n")
+
+	event, err := stream.RecvContext(context.Background())
+	tests.Equal(t, err, nil, "stream should still be usable after a RecvContext timeout")
+	tests.Equal(t, event.Data, "after timeout", "unexpected event data after recovering from timeout")
+}
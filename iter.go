@@ -0,0 +1,59 @@
+package sse
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+// Events returns an iterator over the stream's events, following the same
+// range-over-func shape as the package-level Read function. Iteration stops
+// at a clean io.EOF; any other error is yielded once as the loop's second
+// value before iteration stops, and can also be retrieved afterwards via
+// Err(). Recv remains available for callers that need per-event control.
+func (s *Stream) Events() iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for {
+			event, err := s.Recv()
+			if err == io.EOF {
+				return
+			}
+			if !yield(event, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// All behaves like Events, but watches ctx for the whole iteration so it
+// stops promptly once ctx is cancelled, instead of blocking on the
+// underlying reader.
+func (s *Stream) All(ctx context.Context) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		if err := ctx.Err(); err != nil {
+			yield(Event{}, err)
+			return
+		}
+
+		// Unlike RecvContext, which watches ctx per call, the watch is set up
+		// once here for the whole loop: re-arming it on every Recv would spin
+		// up a fresh watcher goroutine per event for a long-lived stream.
+		if done := ctx.Done(); done != nil {
+			cleanup := s.reader.watch(ctx)
+			defer cleanup()
+		}
+
+		for {
+			event, err := s.Recv()
+			if err != nil && ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			if err == io.EOF {
+				return
+			}
+			if !yield(event, err) || err != nil {
+				return
+			}
+		}
+	}
+}
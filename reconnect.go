@@ -0,0 +1,167 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// ErrMaxRetriesExceeded is returned by ReconnectingStream.Recv when the
+// configured retry budget has been exhausted without a successful
+// reconnect.
+var ErrMaxRetriesExceeded = errors.New("sse: max retries exceeded")
+
+// Dialer opens a new connection to resume an SSE stream, starting from the
+// event identified by lastEventID (empty if no event has been seen yet).
+// Implementations for HTTP transports are expected to send lastEventID as
+// the Last-Event-ID header, per the SSE reconnection protocol.
+type Dialer func(ctx context.Context, lastEventID string) (io.ReadCloser, error)
+
+// ReconnectingStreamConfig configures a ReconnectingStream.
+type ReconnectingStreamConfig struct {
+	// StreamConfig is forwarded to the underlying Stream on every (re)connect.
+	StreamConfig
+
+	// DefaultRetry is used as the reconnect delay until the server sends a
+	// retry field. Defaults to 3 seconds if unset.
+	DefaultRetry time.Duration
+	// MaxRetry caps the delay between reconnect attempts, regardless of
+	// what the server requests or how much backoff has accumulated. Jitter
+	// is added after the cap, so the final delay can exceed MaxRetry by up
+	// to Jitter*MaxRetry.
+	MaxRetry time.Duration
+	// MaxRetries limits the number of consecutive failed reconnect attempts
+	// before Recv gives up and returns ErrMaxRetriesExceeded. Zero means
+	// unlimited retries.
+	MaxRetries int
+	// Backoff multiplies the delay after each failed attempt, e.g. 2 for
+	// exponential backoff. Values <= 1 disable backoff.
+	Backoff float64
+	// Jitter adds up to Jitter*delay of random jitter to each computed
+	// delay, to avoid many clients reconnecting in lockstep. Must be in
+	// [0, 1].
+	Jitter float64
+	// OnReconnect, if set, is called before every reconnect attempt with
+	// the attempt number (starting at 1), the error that triggered the
+	// attempt (the connection's error for attempt 1, the previous dial's
+	// error after that), and the delay about to be awaited.
+	OnReconnect func(attempt int, err error, next time.Duration)
+}
+
+// ReconnectingStream wraps a Stream and transparently reconnects using a
+// Dialer whenever the underlying connection ends or fails, honoring the
+// server-suggested retry interval and resuming from the last seen event ID.
+type ReconnectingStream struct {
+	dial   Dialer
+	cfg    ReconnectingStreamConfig
+	stream *Stream
+	closed bool
+}
+
+// NewReconnectingStream dials the initial connection and returns a stream
+// that reconnects automatically according to cfg when that connection ends.
+func NewReconnectingStream(ctx context.Context, dial Dialer, cfg ReconnectingStreamConfig) (*ReconnectingStream, error) {
+	if cfg.DefaultRetry <= 0 {
+		cfg.DefaultRetry = 3 * time.Second
+	}
+
+	r, err := dial(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconnectingStream{
+		dial:   dial,
+		cfg:    cfg,
+		stream: NewStreamWithConfig(r, &cfg.StreamConfig),
+	}, nil
+}
+
+// Recv returns the next event, transparently reconnecting as many times as
+// the configured retry policy allows. A dropped connection surfaces from
+// Stream.Recv as a transport error, not necessarily io.EOF, so any non-nil
+// error triggers a reconnect attempt. Recv only returns an error when ctx is
+// cancelled, the retry budget is exhausted, or Close has been called.
+func (r *ReconnectingStream) Recv(ctx context.Context) (Event, error) {
+	for {
+		event, err := r.stream.Recv()
+		if err == nil {
+			return event, nil
+		}
+		if r.closed {
+			return Event{}, io.EOF
+		}
+		if ctx.Err() != nil {
+			return Event{}, ctx.Err()
+		}
+
+		if err := r.reconnect(ctx, err); err != nil {
+			return Event{}, err
+		}
+	}
+}
+
+// reconnect retries the dial with backoff until it succeeds, ctx is
+// cancelled, or the retry budget is exhausted.
+func (r *ReconnectingStream) reconnect(ctx context.Context, cause error) error {
+	for attempt := 1; r.cfg.MaxRetries == 0 || attempt <= r.cfg.MaxRetries; attempt++ {
+		delay := r.nextDelay(attempt)
+		if r.cfg.OnReconnect != nil {
+			r.cfg.OnReconnect(attempt, cause, delay)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if r.closed {
+			return io.EOF
+		}
+
+		conn, err := r.dial(ctx, r.stream.lastEventID)
+		if err != nil {
+			cause = err
+			continue
+		}
+
+		r.stream.resetForReconnect(conn, &r.cfg.StreamConfig)
+		return nil
+	}
+
+	return ErrMaxRetriesExceeded
+}
+
+// nextDelay computes the delay before the given reconnect attempt, preferring
+// the server's last observed retry field over DefaultRetry, then applying
+// backoff, the MaxRetry cap, and jitter in that order.
+func (r *ReconnectingStream) nextDelay(attempt int) time.Duration {
+	delay := r.cfg.DefaultRetry
+	if retry := r.stream.Retry(); retry > 0 {
+		delay = retry
+	}
+
+	if r.cfg.Backoff > 1 {
+		delay = time.Duration(float64(delay) * math.Pow(r.cfg.Backoff, float64(attempt-1)))
+	}
+	if r.cfg.MaxRetry > 0 && delay > r.cfg.MaxRetry {
+		delay = r.cfg.MaxRetry
+	}
+	if r.cfg.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * r.cfg.Jitter * float64(delay))
+	}
+
+	return delay
+}
+
+// Close closes the current underlying connection. Subsequent calls to Recv
+// will not reconnect; they return io.EOF instead, even if one was already
+// in progress waiting to dial.
+func (r *ReconnectingStream) Close() error {
+	r.closed = true
+	return r.stream.Close()
+}
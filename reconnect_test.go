@@ -0,0 +1,219 @@
+package sse_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tmaxmax/go-sse"
+	"github.com/tmaxmax/go-sse/internal/tests"
+)
+
+// flakyReadCloser yields data, then fails once with a non-EOF transport
+// error, simulating a dropped connection rather than a clean close.
+type flakyReadCloser struct {
+	data   string
+	pos    int
+	err    error
+	failed bool
+}
+
+func (f *flakyReadCloser) Read(p []byte) (int, error) {
+	if f.pos < len(f.data) {
+		n := copy(p, f.data[f.pos:])
+		f.pos += n
+		return n, nil
+	}
+	if !f.failed {
+		f.failed = true
+		return 0, f.err
+	}
+	return 0, io.EOF
+}
+
+func (f *flakyReadCloser) Close() error { return nil }
+
+func TestReconnectingStream_ReconnectsOnTransportError(t *testing.T) {
+	var dialCount int32
+	var lastSeenID string
+
+	dial := func(_ context.Context, lastEventID string) (io.ReadCloser, error) {
+		n := atomic.AddInt32(&dialCount, 1)
+		lastSeenID = lastEventID
+		if n == 1 {
+			return &flakyReadCloser{data: "id: 1\ndata: first\n\n", err: errors.New("connection reset")}, nil
+		}
+		return newTestReadCloser("data: second\n\n"), nil
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Millisecond,
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	defer rs.Close()
+
+	event, err := rs.Recv(context.Background())
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Data, "first", "unexpected first event data")
+
+	// The connection fails with a non-EOF transport error here, not io.EOF;
+	// Recv must still reconnect instead of returning the error to the caller.
+	event, err = rs.Recv(context.Background())
+	tests.Equal(t, err, nil, "unexpected error after reconnect")
+	tests.Equal(t, event.Data, "second", "unexpected second event data")
+	tests.Equal(t, lastSeenID, "1", "Dialer should receive the last seen event ID")
+	tests.Equal(t, int(dialCount), 2, "expected exactly one reconnect dial")
+}
+
+func TestReconnectingStream_ReconnectsOnCleanEOF(t *testing.T) {
+	var dialCount int32
+
+	dial := func(_ context.Context, _ string) (io.ReadCloser, error) {
+		n := atomic.AddInt32(&dialCount, 1)
+		if n == 1 {
+			return newTestReadCloser("data: first\n\n"), nil
+		}
+		return newTestReadCloser("data: second\n\n"), nil
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Millisecond,
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	defer rs.Close()
+
+	event, err := rs.Recv(context.Background())
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Data, "first", "unexpected first event data")
+
+	event, err = rs.Recv(context.Background())
+	tests.Equal(t, err, nil, "unexpected error after reconnect")
+	tests.Equal(t, event.Data, "second", "unexpected second event data")
+}
+
+func TestReconnectingStream_MaxRetriesExceeded(t *testing.T) {
+	var dialCount int32
+
+	dial := func(_ context.Context, _ string) (io.ReadCloser, error) {
+		n := atomic.AddInt32(&dialCount, 1)
+		if n == 1 {
+			return newTestReadCloser(""), nil
+		}
+		return nil, errors.New("dial failed")
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Millisecond,
+		MaxRetries:   2,
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	defer rs.Close()
+
+	_, err = rs.Recv(context.Background())
+	tests.Equal(t, err, sse.ErrMaxRetriesExceeded, "expected max retries exceeded")
+	tests.Equal(t, int(dialCount), 3, "expected the initial dial plus two failed retries")
+}
+
+func TestReconnectingStream_OnReconnectFiresWithAttemptNumber(t *testing.T) {
+	var attempts []int
+	var dialCount int32
+
+	dial := func(_ context.Context, _ string) (io.ReadCloser, error) {
+		n := atomic.AddInt32(&dialCount, 1)
+		if n == 1 {
+			return newTestReadCloser(""), nil
+		}
+		return newTestReadCloser("data: ok\n\n"), nil
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Millisecond,
+		OnReconnect: func(attempt int, _ error, _ time.Duration) {
+			attempts = append(attempts, attempt)
+		},
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	defer rs.Close()
+
+	_, err = rs.Recv(context.Background())
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, len(attempts), 1, "OnReconnect should fire once")
+	tests.Equal(t, attempts[0], 1, "first reconnect attempt should be numbered 1")
+}
+
+func TestReconnectingStream_BackoffIncreasesDelay(t *testing.T) {
+	var delays []time.Duration
+	var dialCount int32
+
+	dial := func(_ context.Context, _ string) (io.ReadCloser, error) {
+		n := atomic.AddInt32(&dialCount, 1)
+		if n == 1 {
+			return newTestReadCloser(""), nil
+		}
+		if n <= 3 {
+			return nil, errors.New("dial failed")
+		}
+		return newTestReadCloser("data: ok\n\n"), nil
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Millisecond,
+		Backoff:      2,
+		OnReconnect: func(_ int, _ error, next time.Duration) {
+			delays = append(delays, next)
+		},
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	defer rs.Close()
+
+	_, err = rs.Recv(context.Background())
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, len(delays), 3, "expected three reconnect attempts")
+	tests.Expect(t, delays[1] > delays[0], "backoff should increase the delay between attempts")
+	tests.Expect(t, delays[2] > delays[1], "backoff should keep increasing the delay")
+}
+
+func TestReconnectingStream_CloseStopsReconnect(t *testing.T) {
+	var dialCount int32
+
+	dial := func(_ context.Context, _ string) (io.ReadCloser, error) {
+		atomic.AddInt32(&dialCount, 1)
+		return newTestReadCloser(""), nil
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Millisecond,
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	tests.Equal(t, int(dialCount), 1, "expected the initial dial")
+
+	tests.Equal(t, rs.Close(), nil, "unexpected error closing stream")
+
+	// The underlying Stream is now closed and keeps returning io.EOF from
+	// Recv; without the closed check this would reconnect indefinitely
+	// instead of reporting that the stream is done.
+	_, err = rs.Recv(context.Background())
+	tests.Equal(t, err, io.EOF, "expected io.EOF after Close, not a reconnect")
+	tests.Equal(t, int(dialCount), 1, "Close must prevent any further dial attempts")
+}
+
+func TestReconnectingStream_CancelledContextStopsReconnect(t *testing.T) {
+	dial := func(_ context.Context, _ string) (io.ReadCloser, error) {
+		return newTestReadCloser(""), nil
+	}
+
+	rs, err := sse.NewReconnectingStream(context.Background(), dial, sse.ReconnectingStreamConfig{
+		DefaultRetry: time.Hour,
+	})
+	tests.Equal(t, err, nil, "unexpected error creating stream")
+	defer rs.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = rs.Recv(ctx)
+	tests.Equal(t, err, context.Canceled, "expected context.Canceled")
+}
@@ -1,9 +1,11 @@
 package sse
 
 import (
+	"context"
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/tmaxmax/go-sse/internal/parser"
 )
@@ -18,21 +20,61 @@ type StreamConfig struct {
 	// is enough for your needs (e.g. the events you receive don't contain
 	// larger amounts of data).
 	MaxEventSize int
+
+	// OnComment, if set, is called with the text of every comment line
+	// (a line starting with ':') as it is parsed, before the event it
+	// precedes is yielded. Comments are otherwise discarded by Recv and
+	// are commonly used by servers as keep-alives.
+	OnComment func(comment string)
+	// OnRetry, if set, is called with the reconnection time every time
+	// the stream parses a retry field, before the event it precedes is
+	// yielded.
+	OnRetry func(retry time.Duration)
+
+	// BufferPool, if set, is used to obtain the byte buffers Stream uses to
+	// assemble event data internally, instead of allocating and
+	// discarding one per event. Event.Data itself is always copied out of
+	// the buffer before it is returned, so pooling has no effect on its
+	// lifetime. Defaults to a shared sync.Pool-backed implementation; set
+	// NopBufferPool if you'd rather avoid pooling altogether.
+	BufferPool BufferPool
 }
 
 // Stream provides a convenient interface for reading SSE events one by one
 // from an io.ReadCloser. It maintains state between calls to Recv() and
 // handles the SSE protocol parsing internally.
 type Stream struct {
-	reader      io.ReadCloser
-	parser      *parser.Parser
-	lastEventID string
-	closed      bool
-	
+	reader       *ctxReader
+	parser       *parser.Parser
+	lastEventID  string
+	closed       bool // true once the stream will yield no more events
+	readerClosed bool // true once Close has torn down the reader
+	err          error
+
+	// retry holds the most recently observed server-suggested reconnection
+	// time, as parsed from the retry field. It is zero until the server
+	// sends one.
+	retry time.Duration
+
+	onComment func(string)
+	onRetry   func(time.Duration)
+	bufPool   BufferPool
+
 	// Internal state for parsing
-	typ   string
-	sb    strings.Builder
-	dirty bool
+	typ      string
+	data     *[]byte
+	comments []string
+	dirty    bool
+}
+
+// RawEvent extends Event with protocol-level data that Recv discards: the
+// server-suggested reconnection time and any comment lines seen since the
+// previous event, which servers commonly use for keep-alives or side-channel
+// signals.
+type RawEvent struct {
+	Event
+	Retry    time.Duration
+	Comments []string
 }
 
 // Recv reads and returns the next event from the stream.
@@ -40,16 +82,36 @@ type Stream struct {
 // The Event.LastEventID field is maintained across calls, following
 // the SSE specification behavior.
 func (s *Stream) Recv() (Event, error) {
+	raw, err := s.RecvRaw()
+	return raw.Event, err
+}
+
+// RecvRaw behaves like Recv, but also returns the retry interval and any
+// comment lines associated with the event, instead of silently discarding
+// them. OnComment and OnRetry, if set on the StreamConfig, fire independently
+// of this method as the fields are parsed.
+func (s *Stream) RecvRaw() (RawEvent, error) {
 	if s.closed {
-		return Event{}, io.EOF
+		if s.err != nil {
+			return RawEvent{}, s.err
+		}
+		return RawEvent{}, io.EOF
 	}
 
 	for {
 		f := parser.Field{}
 		if !s.parser.Next(&f) {
-			err := s.parser.Err()
-			isEOF := err == io.EOF
-			isUnexpectedEOF := err == parser.ErrUnexpectedEOF
+			perr := s.parser.Err()
+			isEOF := perr == io.EOF
+			isUnexpectedEOF := perr == parser.ErrUnexpectedEOF
+
+			// A salvaged dirty event at unexpected EOF means the connection
+			// didn't end cleanly; record that for Err(), but still yield the
+			// salvaged event (if any) and let the following call report a
+			// plain io.EOF, same as a clean close.
+			if isUnexpectedEOF {
+				s.err = parser.ErrUnexpectedEOF
+			}
 
 			// If we have a dirty event at EOF or unexpected EOF, yield it
 			if s.dirty && (isEOF || isUnexpectedEOF) {
@@ -58,16 +120,28 @@ func (s *Stream) Recv() (Event, error) {
 				return event, nil
 			}
 
-			if err != nil && !isEOF && !isUnexpectedEOF {
-				return Event{}, err
+			if perr != nil && !isEOF && !isUnexpectedEOF {
+				// A RecvContext/All cancellation surfaces here too, since it
+				// aborts the read with an error. Unlike a genuine transport
+				// failure it doesn't mean the stream is unusable, so it must
+				// not stick the way s.err/s.closed otherwise would: the next
+				// call, with a fresh, non-done context, should work normally.
+				if !isContextErr(perr) {
+					s.err = perr
+					s.closed = true
+				}
+				return RawEvent{}, perr
 			}
-			return Event{}, io.EOF
+			return RawEvent{}, io.EOF
 		}
 
 		switch f.Name {
 		case parser.FieldNameData:
-			s.sb.WriteString(f.Value)
-			s.sb.WriteByte('\n')
+			if s.data == nil {
+				s.data = s.bufPool.Get(len(f.Value) + 1)
+			}
+			*s.data = append(*s.data, f.Value...)
+			*s.data = append(*s.data, '\n')
 			s.dirty = true
 		case parser.FieldNameEvent:
 			s.typ = f.Value
@@ -80,9 +154,17 @@ func (s *Stream) Recv() (Event, error) {
 				s.dirty = true
 			}
 		case parser.FieldNameRetry:
-			// Parse retry field but don't handle it in Stream (similar to Read function)
-			if _, err := strconv.ParseInt(f.Value, 10, 64); err == nil {
+			if ms, err := strconv.ParseInt(f.Value, 10, 64); err == nil {
+				s.retry = time.Duration(ms) * time.Millisecond
 				s.dirty = true
+				if s.onRetry != nil {
+					s.onRetry(s.retry)
+				}
+			}
+		case parser.FieldNameComment:
+			s.comments = append(s.comments, f.Value)
+			if s.onComment != nil {
+				s.onComment(f.Value)
 			}
 		default:
 			// End of event - yield if we have data
@@ -95,31 +177,112 @@ func (s *Stream) Recv() (Event, error) {
 	}
 }
 
-// buildEvent constructs an Event from the current state
-func (s *Stream) buildEvent() Event {
-	data := s.sb.String()
-	if data != "" {
-		data = data[:len(data)-1] // Remove trailing newline
+// RecvContext behaves like Recv, but returns ctx.Err() promptly if ctx is
+// cancelled before an event arrives, instead of blocking until the
+// underlying reader returns. This is useful when the reader has no
+// deadline support of its own, e.g. a plain network socket governed by a
+// request context.
+func (s *Stream) RecvContext(ctx context.Context) (Event, error) {
+	if s.closed {
+		return s.Recv()
+	}
+	if err := ctx.Err(); err != nil {
+		return Event{}, err
+	}
+
+	if done := ctx.Done(); done != nil {
+		cleanup := s.reader.watch(ctx)
+		defer cleanup()
+	}
+
+	event, err := s.Recv()
+	if err != nil && ctx.Err() != nil {
+		return Event{}, ctx.Err()
+	}
+	return event, err
+}
+
+// buildEvent constructs a RawEvent from the current state
+func (s *Stream) buildEvent() RawEvent {
+	var data string
+	if s.data != nil {
+		b := *s.data
+		if len(b) > 0 {
+			b = b[:len(b)-1] // Remove trailing newline
+		}
+		data = string(b)
 	}
-	return Event{
-		LastEventID: s.lastEventID,
-		Type:        s.typ,
-		Data:        data,
+	return RawEvent{
+		Event: Event{
+			LastEventID: s.lastEventID,
+			Type:        s.typ,
+			Data:        data,
+		},
+		Retry:    s.retry,
+		Comments: s.comments,
 	}
 }
 
-// resetState resets the internal parsing state for the next event
+// resetState resets the internal parsing state for the next event, returning
+// the data buffer to the pool since buildEvent has already copied out of it.
 func (s *Stream) resetState() {
-	s.sb.Reset()
+	if s.data != nil {
+		s.bufPool.Put(s.data)
+		s.data = nil
+	}
 	s.typ = ""
+	s.comments = nil
 	s.dirty = false
 }
 
-// Close closes the underlying reader and marks the stream as closed.
+// Retry returns the most recently observed server-suggested reconnection
+// time. It is zero if the server hasn't sent a retry field yet.
+func (s *Stream) Retry() time.Duration {
+	return s.retry
+}
+
+// Err returns the last non-EOF error observed by Recv/RecvRaw, including the
+// parser's ErrUnexpectedEOF when the input ended mid-event, which Recv
+// otherwise reports as a clean io.EOF. It is nil if the
+// stream hasn't failed. Calling Err() never closes the reader, and its
+// result remains available both before and after Close().
+func (s *Stream) Err() error {
+	return s.err
+}
+
+// resetForReconnect rebinds the stream to a freshly dialed reader, discarding
+// any in-progress event, while preserving state that must survive a
+// reconnect: the last event ID and the last observed retry interval.
+func (s *Stream) resetForReconnect(r io.ReadCloser, cfg *StreamConfig) {
+	s.reader, s.parser = newStreamReader(r, cfg)
+	s.closed = false
+	s.readerClosed = false
+	s.err = nil
+	s.applyCallbacks(cfg)
+	s.resetState()
+}
+
+// applyCallbacks wires the OnComment/OnRetry hooks and BufferPool from cfg.
+func (s *Stream) applyCallbacks(cfg *StreamConfig) {
+	s.bufPool = defaultBufferPool
+	if cfg == nil {
+		return
+	}
+	s.onComment = cfg.OnComment
+	s.onRetry = cfg.OnRetry
+	if cfg.BufferPool != nil {
+		s.bufPool = cfg.BufferPool
+	}
+}
+
+// Close closes the underlying reader and marks the stream as closed. It is
+// idempotent, and does not affect the error reported by Err() — call Err()
+// either before or after Close() to find out why the stream ended.
 func (s *Stream) Close() error {
-	if s.closed {
+	if s.readerClosed {
 		return nil
 	}
+	s.readerClosed = true
 	s.closed = true
 	return s.reader.Close()
 }
@@ -131,13 +294,25 @@ func NewStream(r io.ReadCloser) *Stream {
 
 // NewStreamWithConfig creates a new Stream from an io.ReadCloser with the given configuration.
 func NewStreamWithConfig(r io.ReadCloser, cfg *StreamConfig) *Stream {
-	p := parser.New(r)
+	reader, p := newStreamReader(r, cfg)
+
+	s := &Stream{
+		reader: reader,
+		parser: p,
+	}
+	s.applyCallbacks(cfg)
+	return s
+}
+
+// newStreamReader wraps r for context-cancellable reads and builds the
+// parser that will consume it, applying cfg's buffer size if set.
+func newStreamReader(r io.ReadCloser, cfg *StreamConfig) (*ctxReader, *parser.Parser) {
+	reader := newCtxReader(r)
+
+	p := parser.New(reader)
 	if cfg != nil && cfg.MaxEventSize > 0 {
 		p.Buffer(nil, cfg.MaxEventSize)
 	}
 
-	return &Stream{
-		reader: r,
-		parser: p,
-	}
+	return reader, p
 }
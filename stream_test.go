@@ -1,9 +1,11 @@
 package sse_test
 
 import (
+	"context"
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/tmaxmax/go-sse"
 	"github.com/tmaxmax/go-sse/internal/tests"
@@ -317,6 +319,110 @@ data: World
 	tests.Equal(t, event.Data, "Hello\nWorld", "comments should be ignored")
 }
 
+func TestStream_RecvRaw_RetryAndComments(t *testing.T) {
+	input := ": heartbeat\nretry: 1500\ndata: Hello\n\n"
+	r := newTestReadCloser(input)
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	raw, err := stream.RecvRaw()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, raw.Data, "Hello", "unexpected event data")
+	tests.Equal(t, raw.Retry, 1500*time.Millisecond, "unexpected retry duration")
+	tests.Equal(t, len(raw.Comments), 1, "unexpected comment count")
+	tests.Equal(t, raw.Comments[0], " heartbeat", "unexpected comment text")
+}
+
+func TestStream_OnCommentAndOnRetry(t *testing.T) {
+	input := ": ping\nretry: 2000\ndata: Hello\n\n"
+	r := newTestReadCloser(input)
+
+	var gotComment string
+	var gotRetry time.Duration
+	cfg := &sse.StreamConfig{
+		OnComment: func(c string) { gotComment = c },
+		OnRetry:   func(d time.Duration) { gotRetry = d },
+	}
+	stream := sse.NewStreamWithConfig(r, cfg)
+	defer stream.Close()
+
+	_, err := stream.Recv()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, gotComment, " ping", "OnComment should receive the comment text")
+	tests.Equal(t, gotRetry, 2000*time.Millisecond, "OnRetry should receive the parsed duration")
+}
+
+func TestStream_Err_DoesNotCloseReader(t *testing.T) {
+	r := newTestReadCloser("data: test\n\n")
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	tests.Equal(t, stream.Err(), nil, "Err should be nil before any failure")
+	tests.Equal(t, stream.Err(), nil, "calling Err() repeatedly should not close the reader")
+	tests.Expect(t, !r.closed, "Err() must not close the underlying reader")
+}
+
+func TestStream_Err_CallableAfterClose(t *testing.T) {
+	r := newTestReadCloser("data: test\n\n")
+	stream := sse.NewStream(r)
+
+	_, _ = stream.Recv()
+	err := stream.Close()
+	tests.Equal(t, err, nil, "unexpected error closing stream")
+	tests.Equal(t, stream.Err(), nil, "Err() should still be callable after Close()")
+}
+
+func TestStream_Err_UnexpectedEOFNotMaskedAsCleanEOF(t *testing.T) {
+	// No trailing blank line: the connection was cut off mid-event.
+	input := "data: Final event"
+	r := newTestReadCloser(input)
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	event, err := stream.Recv()
+	tests.Equal(t, err, nil, "unexpected error")
+	tests.Equal(t, event.Data, "Final event", "unexpected salvaged event data")
+
+	_, err = stream.Recv()
+	tests.Equal(t, err, io.EOF, "Recv should still report a plain io.EOF")
+	tests.Expect(t, stream.Err() != nil, "Err() should surface that the stream didn't end cleanly")
+}
+
+func TestStream_Events(t *testing.T) {
+	input := "data: First event\n\ndata: Second event\n\n"
+	r := newTestReadCloser(input)
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	var got []string
+	for event, err := range stream.Events() {
+		tests.Equal(t, err, nil, "unexpected error iterating events")
+		got = append(got, event.Data)
+	}
+
+	tests.Equal(t, len(got), 2, "unexpected number of events")
+	tests.Equal(t, got[0], "First event", "unexpected first event data")
+	tests.Equal(t, got[1], "Second event", "unexpected second event data")
+}
+
+func TestStream_All_StopsOnCancelledContext(t *testing.T) {
+	r := newTestReadCloser("data: test\n\n")
+	stream := sse.NewStream(r)
+	defer stream.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var callCount int
+	for _, err := range stream.All(ctx) {
+		callCount++
+		tests.Equal(t, err, context.Canceled, "expected context.Canceled")
+		break
+	}
+
+	tests.Expect(t, callCount <= 1, "iteration should stop promptly after cancellation")
+}
+
 // Benchmark tests
 func BenchmarkStream_Recv_SimpleEvent(b *testing.B) {
 	input := "data: Hello World!\n\n"